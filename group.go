@@ -0,0 +1,116 @@
+package jsonerror
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type token struct{}
+
+// Group runs a collection of goroutines and collects whatever errors they
+// return into an ErrorCollection, instead of keeping only the first one
+// like golang.org/x/sync/errgroup does. It is safe for concurrent use by
+// multiple goroutines, since AddError already is.
+type Group struct {
+	cancel context.CancelFunc
+
+	wg sync.WaitGroup
+
+	sem chan token
+
+	ec *ErrorCollection
+}
+
+// NewGroup returns a new Group and an associated Context derived from ctx.
+// The derived Context is canceled the first time a function passed to Go
+// returns a non-nil error or the first time Wait returns, whichever occurs
+// first.
+// dup is forwarded to NewErrorCollection and controls whether errors
+// returned by Go are deduplicated.
+func NewGroup(ctx context.Context, dup ...DuplicatationOptions) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel, ec: NewErrorCollection(dup...)}, ctx
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// Go calls the given function in a new goroutine.
+// If f returns a non-nil error, it is added to the Group's ErrorCollection
+// (via AddError, so duplicate options are respected) and the Group's
+// Context, if any, is canceled.
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- token{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+
+		if err := f(); err != nil {
+			g.ec.AddError(err)
+			if g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines in the group is currently below the limit set by
+// SetLimit. It returns false, without calling f, if the limit has been
+// reached.
+func (g *Group) TryGo(f func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- token{}:
+		default:
+			return false
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+
+		if err := f(); err != nil {
+			g.ec.AddError(err)
+			if g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+	return true
+}
+
+// SetLimit limits the number of active goroutines in this group to at most
+// n. A negative value indicates no limit. SetLimit must not be called
+// concurrently with Go or TryGo, or while any goroutines launched by them
+// are still active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic(fmt.Errorf("jsonerror: SetLimit called while %d goroutines are still active", len(g.sem)))
+	}
+	g.sem = make(chan token, n)
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns the Group's ErrorCollection. Use IsNil on the result to
+// check whether any error was collected.
+func (g *Group) Wait() *ErrorCollection {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.ec
+}
@@ -0,0 +1,66 @@
+package jsonerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGroupCollectsAllErrors(t *testing.T) {
+	g, _ := NewGroup(context.Background())
+
+	for i := 0; i < 5; i++ {
+		i := i
+		g.Go(func() error {
+			if i%2 == 0 {
+				return nil
+			}
+			return fmt.Errorf("failed on %d", i)
+		})
+	}
+
+	ec := g.Wait()
+	if IsNil(ec) {
+		t.Fatalf("expected errors from the odd-numbered goroutines")
+	}
+	if len(ec.Errors) != 2 {
+		t.Fatalf("got %d errors, want 2", len(ec.Errors))
+	}
+}
+
+func TestGroupCancelsContextOnError(t *testing.T) {
+	g, ctx := NewGroup(context.Background())
+
+	boom := errors.New("boom")
+	g.Go(func() error {
+		return boom
+	})
+
+	g.Wait()
+
+	if ctx.Err() == nil {
+		t.Fatalf("expected the Group's context to be canceled after an error")
+	}
+}
+
+func TestGroupSetLimit(t *testing.T) {
+	g, _ := NewGroup(context.Background())
+	g.SetLimit(1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	g.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	<-started
+	if g.TryGo(func() error { return nil }) {
+		t.Fatalf("expected TryGo to fail while the limit is reached")
+	}
+	close(release)
+
+	g.Wait()
+}
@@ -0,0 +1,131 @@
+// Package grpcstatus bridges jsonerror's JE and ErrorCollection to
+// google.golang.org/grpc/status, so a service can return a *status.Status
+// built from one or many JE errors, and a client can recover the original
+// JE(s) from a *status.Status it receives.
+//
+// JE's Code field is an opaque application-level number, so it can't be
+// mapped onto gRPC's codes.Code automatically. CodeMapper
+// lets callers supply that mapping; by default every JE is reported as
+// codes.Unknown.
+package grpcstatus
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/pjebs/jsonerror"
+)
+
+// errorInfoReason identifies details produced by this package within a
+// *status.Status, so FromStatus knows which ones to look at.
+const errorInfoReason = "JSONERROR"
+
+// CodeMapper converts a JE's Code into a canonical gRPC codes.Code.
+// It defaults to always returning codes.Unknown; replace it with a
+// function that understands your application's codes if you need the
+// gRPC status code itself to be meaningful.
+var CodeMapper = func(code int) codes.Code {
+	return codes.Unknown
+}
+
+// GRPCStatus builds a *status.Status for j. Domain, message and (if
+// DisplayTime is set) the timestamp are packed into an ErrorInfo detail,
+// so FromStatus can reconstruct an equivalent JE from it.
+func GRPCStatus(j jsonerror.JE) *status.Status {
+	st := status.New(CodeMapper(j.Code), j.Error())
+
+	withDetails, err := st.WithDetails(errorInfo(j))
+	if err != nil {
+		// Can only fail if errorInfo can't be marshaled as an Any, which
+		// never happens for a well-formed ErrorInfo.
+		return st
+	}
+	return withDetails
+}
+
+// ErrorCollectionGRPCStatus builds a single *status.Status for ec, with
+// every contained JE packed in as its own ErrorInfo detail (in the same
+// form GRPCStatus uses), so a service can return one call's worth of
+// accumulated JE errors over gRPC. Any contained error that isn't a JE is
+// packed in as a DebugInfo detail instead, so it isn't silently dropped.
+func ErrorCollectionGRPCStatus(ec *jsonerror.ErrorCollection) *status.Status {
+	st := status.New(codes.Unknown, ec.Error())
+
+	var details []protoadapt.MessageV1
+	for _, err := range ec.Unwrap() {
+		switch v := err.(type) {
+		case jsonerror.JE:
+			details = append(details, errorInfo(v))
+		case *jsonerror.JE:
+			details = append(details, errorInfo(*v))
+		default:
+			details = append(details, &errdetails.DebugInfo{Detail: err.Error()})
+		}
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// FromStatus recovers the JE packed into s by GRPCStatus. If s carries no
+// such detail (e.g. it didn't originate from this package), it falls back
+// to a JE built from s's plain gRPC code and message.
+func FromStatus(s *status.Status) jsonerror.JE {
+	for _, d := range s.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.Reason != errorInfoReason {
+			continue
+		}
+
+		code, _ := strconv.Atoi(info.Metadata["code"])
+		errStr := info.Metadata["error"]
+		message := info.Metadata["message"]
+
+		if ts, displayTime := info.Metadata["time"]; displayTime {
+			// JE's timestamp is unexported, so restore it through
+			// UnmarshalJSON, whose RFC3339 parsing also accepts the
+			// fractional seconds written by errorInfo.
+			data, err := json.Marshal(map[string]interface{}{"code": code, "error": errStr, "message": message, "domain": info.Domain, "time": ts})
+			var j jsonerror.JE
+			if err == nil && j.UnmarshalJSON(data) == nil {
+				return j
+			}
+			return jsonerror.NewAndDisplayTime(code, errStr, message, info.Domain)
+		}
+		return jsonerror.New(code, errStr, message, info.Domain)
+	}
+
+	return jsonerror.New(int(s.Code()), s.Message(), "")
+}
+
+// errorInfo renders j into the ErrorInfo detail shared by GRPCStatus and
+// ErrorCollectionGRPCStatus.
+func errorInfo(j jsonerror.JE) *errdetails.ErrorInfo {
+	rendered := j.Render()
+
+	metadata := map[string]string{"code": rendered["code"]}
+	if e, ok := rendered["error"]; ok {
+		metadata["error"] = e
+	}
+	if m, ok := rendered["message"]; ok {
+		metadata["message"] = m
+	}
+	if j.DisplayTime {
+		metadata["time"] = j.Time().Format(time.RFC3339Nano)
+	}
+
+	return &errdetails.ErrorInfo{
+		Reason:   errorInfoReason,
+		Domain:   j.Domain,
+		Metadata: metadata,
+	}
+}
@@ -0,0 +1,37 @@
+package grpcstatus
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/pjebs/jsonerror"
+)
+
+func TestGRPCStatusRoundTrip(t *testing.T) {
+	want := jsonerror.New(42, "oops", "something broke", "mydomain")
+
+	st := GRPCStatus(want)
+	got := FromStatus(st)
+
+	if got.Code != want.Code || got.Domain != want.Domain {
+		t.Fatalf("got Code=%d Domain=%q, want Code=%d Domain=%q", got.Code, got.Domain, want.Code, want.Domain)
+	}
+	if gotRendered, wantRendered := got.Render(), want.Render(); gotRendered["error"] != wantRendered["error"] || gotRendered["message"] != wantRendered["message"] {
+		t.Fatalf("got %v, want %v", gotRendered, wantRendered)
+	}
+}
+
+func TestErrorCollectionGRPCStatus(t *testing.T) {
+	ec := jsonerror.NewErrorCollection()
+	ec.AddError(jsonerror.New(1, "first", ""))
+	ec.AddError(jsonerror.New(2, "second", ""))
+
+	st := ErrorCollectionGRPCStatus(ec)
+	if st.Code() != codes.Unknown {
+		t.Fatalf("got code %v, want %v", st.Code(), codes.Unknown)
+	}
+	if len(st.Details()) != 2 {
+		t.Fatalf("got %d details, want 2", len(st.Details()))
+	}
+}
@@ -1,6 +1,8 @@
 package jsonerror
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
@@ -44,6 +46,17 @@ func NewErrorCollection(dup ...DuplicatationOptions) *ErrorCollection {
 	return ec
 }
 
+// sameCause reports whether two JE.cause values (as set by Wrap) should be
+// treated as the same underlying error for duplicate detection. Two JEs
+// wrapping different causes are never duplicates, even if their Code,
+// Domain, error and message otherwise match.
+func sameCause(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return errors.Is(a, b) || errors.Is(b, a)
+}
+
 // Append an error to the error collection without locking
 func (ec *ErrorCollection) addError(err error) {
 	
@@ -81,7 +94,7 @@ func (ec *ErrorCollection) addError(err error) {
 
 			if je1 != nil && je2 != nil {
 				//Don't use Reflection since both are JE structs
-				if (*je1).Code == (*je2).Code && (*je1).Domain == (*je2).Domain && (*je1).error == (*je2).error && (*je1).message == (*je2).message {
+				if (*je1).Code == (*je2).Code && (*je1).Domain == (*je2).Domain && (*je1).error == (*je2).error && (*je1).message == (*je2).message && sameCause((*je1).cause, (*je2).cause) {
 					if ec.DuplicatationOptions == RejectDuplicates {
 						if (*je1).time.Equal((*je2).time) {
 							//Both JE structs are 100% identical including timestamp
@@ -97,6 +110,12 @@ func (ec *ErrorCollection) addError(err error) {
 				if reflect.DeepEqual(containedErr, err) {
 					return
 				}
+
+				//Fall back to the standard wrapping chain, so a wrapped
+				//duplicate (e.g. via Wrap or fmt.Errorf's %w) is still caught
+				if errors.Is(err, containedErr) || errors.Is(containedErr, err) {
+					return
+				}
 			}
 		}
 	}
@@ -152,6 +171,18 @@ func (ec *ErrorCollection) Error() string {
 	return str
 }
 
+// Unwrap returns every error contained in the collection, so that the
+// standard errors.Is and errors.As (Go 1.20's multi-error support) visit
+// each of them in turn.
+func (ec *ErrorCollection) Unwrap() []error {
+	ec.lock.RLock()
+	defer ec.lock.RUnlock()
+
+	errs := make([]error, len(ec.Errors))
+	copy(errs, ec.Errors)
+	return errs
+}
+
 // IsNil returns whether an error is nil or not.
 // It can be used with ErrorCollection or generic errors
 func IsNil(err error) bool {
@@ -180,6 +211,7 @@ type JE struct {
 	message     string
 	time        time.Time //Displayed as Unix timestamp (number of nanoseconds elapsed since January 1, 1970 UTC)
 	DisplayTime bool
+	cause       error
 }
 
 // New creates a new JE struct.
@@ -192,6 +224,17 @@ func New(code int, error string, message string, domain ...string) JE {
 	return j
 }
 
+// Wrap creates a new JE struct that wraps err, so that errors.Unwrap,
+// errors.Is and errors.As can still reach it.
+// Domain is optional but can be at most 1 string.
+func Wrap(err error, code int, msg, message string, domain ...string) JE {
+	j := JE{Code: code, error: msg, message: message, time: time.Now().UTC(), cause: err}
+	if len(domain) != 0 {
+		j.Domain = domain[0]
+	}
+	return j
+}
+
 // NewAndDisplayTime creates a new JE struct and configures it to display the timestamp.
 // Domain is optional but can be at most 1 string.
 func NewAndDisplayTime(code int, error string, message string, domain ...string) JE {
@@ -231,6 +274,85 @@ func (j JE) Time() time.Time {
 	return j.time
 }
 
+// Unwrap returns the error passed to Wrap, if any, so that the standard
+// errors.Unwrap/errors.Is/errors.As can follow the wrapping chain through a JE.
+func (j JE) Unwrap() error {
+	return j.cause
+}
+
+// Is reports whether target is a JE (or *JE) with the same Code.
+// If target also has a Domain set, it must match j's Domain too.
+// It lets errors.Is(err, jsonerror.New(code, ...)) match any JE sharing
+// that Code, regardless of message or timestamp.
+func (j JE) Is(target error) bool {
+	var t JE
+	switch v := target.(type) {
+	case JE:
+		t = v
+	case *JE:
+		if v == nil {
+			return false
+		}
+		t = *v
+	default:
+		return false
+	}
+
+	if j.Code != t.Code {
+		return false
+	}
+	if t.Domain != "" && j.Domain != t.Domain {
+		return false
+	}
+	return true
+}
+
+// jeJSON is the wire representation used by JE's MarshalJSON/UnmarshalJSON.
+type jeJSON struct {
+	Code    int    `json:"code"`
+	Error   string `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	Time    string `json:"time,omitempty"`
+}
+
+// MarshalJSON implements encoding/json.Marshaler, so a JE can be sent over
+// the wire with the stdlib json package or any JSON-based transport
+// without going through Render. The timestamp is only included when
+// DisplayTime is set, and is formatted as RFC3339.
+func (j JE) MarshalJSON() ([]byte, error) {
+	aux := jeJSON{Code: j.Code, Error: j.error, Message: j.message, Domain: j.Domain}
+	if j.DisplayTime {
+		aux.Time = j.time.Format(time.RFC3339)
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler, the inverse of
+// MarshalJSON. DisplayTime is set to true when the decoded payload carries
+// a time field.
+func (j *JE) UnmarshalJSON(data []byte) error {
+	var aux jeJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	j.Code = aux.Code
+	j.error = aux.Error
+	j.message = aux.Message
+	j.Domain = aux.Domain
+
+	if aux.Time != "" {
+		t, err := time.Parse(time.RFC3339, aux.Time)
+		if err != nil {
+			return err
+		}
+		j.time = t
+		j.DisplayTime = true
+	}
+	return nil
+}
+
 //For use with package: "gopkg.in/unrolled/render.v1".
 //Can easily output properly formatted JSON error messages for REST API services.
 func (j JE) Render() map[string]string {
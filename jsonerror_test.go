@@ -0,0 +1,87 @@
+package jsonerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWrapIsAs(t *testing.T) {
+	cause := errors.New("boom")
+	wrapped := Wrap(cause, 42, "oops", "something broke")
+
+	if !errors.Is(wrapped, cause) {
+		t.Fatalf("expected errors.Is to find the wrapped cause")
+	}
+
+	if !errors.Is(wrapped, New(42, "", "")) {
+		t.Fatalf("expected errors.Is to match a JE target by Code")
+	}
+
+	if errors.Is(wrapped, New(43, "", "")) {
+		t.Fatalf("expected errors.Is to reject a JE target with a different Code")
+	}
+
+	ec := NewErrorCollection()
+	ec.AddError(wrapped)
+	ec.AddError(errors.New("unrelated"))
+
+	if !errors.Is(ec, cause) {
+		t.Fatalf("expected errors.Is to traverse the ErrorCollection to the wrapped cause")
+	}
+
+	var je JE
+	if !errors.As(ec, &je) {
+		t.Fatalf("expected errors.As to find the JE inside the ErrorCollection")
+	}
+	if je.Code != 42 {
+		t.Fatalf("got Code %d, want 42", je.Code)
+	}
+}
+
+func TestAddErrorDedupWrapped(t *testing.T) {
+	cause := errors.New("boom")
+
+	ec := NewErrorCollection(RejectDuplicates)
+	ec.AddError(cause)
+	ec.AddError(fmt.Errorf("context: %w", cause))
+
+	if len(ec.Errors) != 1 {
+		t.Fatalf("expected the wrapped duplicate to be rejected, got %d errors", len(ec.Errors))
+	}
+}
+
+func TestAddErrorKeepsWrapsWithDistinctCauses(t *testing.T) {
+	ec := NewErrorCollection(RejectDuplicatesIgnoreTimestamp)
+
+	ec.AddError(Wrap(errors.New("disk full"), 500, "internal error", "try again"))
+	ec.AddError(Wrap(errors.New("network timeout"), 500, "internal error", "try again"))
+
+	if len(ec.Errors) != 2 {
+		t.Fatalf("expected both wraps to be kept since their causes differ, got %d errors", len(ec.Errors))
+	}
+}
+
+func TestMarshalUnmarshalJSON(t *testing.T) {
+	want := NewAndDisplayTime(42, "oops", "something broke", "mydomain")
+
+	data, err := want.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var got JE
+	if err := got.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	if got.Code != want.Code || got.Domain != want.Domain {
+		t.Fatalf("got Code=%d Domain=%q, want Code=%d Domain=%q", got.Code, got.Domain, want.Code, want.Domain)
+	}
+	if gotRendered, wantRendered := got.Render(), want.Render(); gotRendered["error"] != wantRendered["error"] || gotRendered["message"] != wantRendered["message"] {
+		t.Fatalf("got %v, want %v", gotRendered, wantRendered)
+	}
+	if !got.DisplayTime {
+		t.Fatalf("expected DisplayTime to round-trip as true")
+	}
+}